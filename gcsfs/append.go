@@ -0,0 +1,107 @@
+package gcsfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/storage"
+	"github.com/gkalele/dfs/dfsapi"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+// composeLimit is the maximum number of source objects GCS allows in a
+// single compose operation. Each append adds one component to the
+// destination, so once it's within one of the limit the destination must be
+// compacted before the next append can proceed.
+const composeLimit = 32
+
+// appendWriter buffers an append's new bytes into a temporary object, then
+// composes it onto the target object on Close, giving callers append-only
+// log semantics without a client-side read-modify-write.
+type appendWriter struct {
+	g       *GCS
+	ctx     context.Context
+	client  *TransactionClient
+	name    string
+	tmpName string
+	tmp     *storage.Writer
+}
+
+// Append returns a FileWriter whose bytes are appended to the existing
+// object name via server-side composition: the new bytes are written to a
+// temporary object, which is then composed onto name and deleted. If name
+// does not yet exist, Append falls back to a plain Create.
+func (g *GCS) Append(ctx context.Context, name string) (dfsapi.FileWriter, error) {
+	client, err := g.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.bucket.Object(name).Attrs(ctx); err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return g.Create(ctx, name)
+		}
+		return nil, err
+	}
+	tmpName := fmt.Sprintf("%s.append-%s", name, uuid.New())
+	return &appendWriter{
+		g:       g,
+		ctx:     ctx,
+		client:  client,
+		name:    name,
+		tmpName: tmpName,
+		tmp:     client.bucket.Object(tmpName).NewWriter(ctx),
+	}, nil
+}
+
+func (w *appendWriter) Write(p []byte) (int, error) {
+	return w.tmp.Write(p)
+}
+
+func (w *appendWriter) Close() error {
+	if err := w.tmp.Close(); err != nil {
+		return err
+	}
+	tmpObj := w.client.bucket.Object(w.tmpName)
+	defer func() {
+		if err := tmpObj.Delete(w.ctx); err != nil {
+			glog.Warningf("gcsfs: append: failed to delete temp object %s: %s", w.tmpName, err)
+		}
+	}()
+
+	if err := w.g.compactIfNeeded(w.ctx, w.client, w.name); err != nil {
+		return err
+	}
+	orig := w.client.bucket.Object(w.name)
+	_, err := orig.ComposerFrom(orig, tmpObj).Run(w.ctx)
+	return err
+}
+
+// compactIfNeeded resets name's component count to 1 if it is within one
+// append of composeLimit. A compose's destination component count is the
+// sum of its sources' counts, so composing an object with itself does
+// nothing to reduce it; a Copy, by contrast, always produces a plain
+// single-component object, so compaction rewrites name onto itself via
+// CopierFrom rather than ComposerFrom.
+func (g *GCS) compactIfNeeded(ctx context.Context, client *TransactionClient, name string) error {
+	attrs, err := client.bucket.Object(name).Attrs(ctx)
+	if err != nil {
+		return err
+	}
+	if !needsCompaction(attrs.ComponentCount) {
+		return nil
+	}
+	self := client.bucket.Object(name)
+	if _, err := self.CopierFrom(self).Run(ctx); err != nil {
+		return fmt.Errorf("gcsfs: append: failed to compact %s before appending: %w", name, err)
+	}
+	return nil
+}
+
+// needsCompaction reports whether an object with the given component count
+// is within one append of composeLimit and must be compacted before the
+// next append can proceed.
+func needsCompaction(componentCount int64) bool {
+	return componentCount >= composeLimit-1
+}
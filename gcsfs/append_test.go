@@ -0,0 +1,23 @@
+package gcsfs
+
+import "testing"
+
+func TestNeedsCompaction(t *testing.T) {
+	cases := []struct {
+		name           string
+		componentCount int64
+		want           bool
+	}{
+		{"well below limit", 1, false},
+		{"just below threshold", composeLimit - 2, false},
+		{"at threshold", composeLimit - 1, true},
+		{"at limit", composeLimit, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := needsCompaction(c.componentCount); got != c.want {
+				t.Errorf("needsCompaction(%d) = %v, want %v", c.componentCount, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package gcsfs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultMaxConcurrency is the worker pool size used by RemoveAll, Rename
+// and CopyPrefix when MaxConcurrency is left at its zero value.
+const DefaultMaxConcurrency = 50
+
+// MultiError aggregates the errors from a bounded-concurrency operation
+// that kept going (or had in-flight work) after its first failure.
+type MultiError struct {
+	Errors []error
+}
+
+func (m *MultiError) Error() string {
+	if len(m.Errors) == 1 {
+		return m.Errors[0].Error()
+	}
+	msgs := make([]string, len(m.Errors))
+	for i, err := range m.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(m.Errors), strings.Join(msgs, "; "))
+}
+
+func (g *GCS) maxConcurrency() int {
+	if g.MaxConcurrency > 0 {
+		return g.MaxConcurrency
+	}
+	return DefaultMaxConcurrency
+}
+
+// parallelDo runs fn once per item, bounded to g.maxConcurrency() concurrent
+// calls via errgroup's own worker limit, in the style of the Docker
+// distribution GCS driver's throttled bulk operations. The context passed
+// to fn is cancelled as soon as any call returns an error, but parallelDo
+// still waits for every call to finish and returns every error it saw as a
+// *MultiError.
+func (g *GCS) parallelDo(ctx context.Context, items []string, fn func(ctx context.Context, item string) error) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(g.maxConcurrency())
+
+	var mu sync.Mutex
+	var errs []error
+
+	for _, item := range items {
+		item := item
+		group.Go(func() error {
+			if err := fn(groupCtx, item); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", item, err))
+				mu.Unlock()
+				return err
+			}
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: errs}
+}
@@ -0,0 +1,78 @@
+package gcsfs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallelDoAllSucceed(t *testing.T) {
+	g := &GCS{}
+	items := []string{"a", "b", "c", "d"}
+	var seen int32
+	err := g.parallelDo(context.Background(), items, func(ctx context.Context, item string) error {
+		atomic.AddInt32(&seen, 1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("got err %v, want nil", err)
+	}
+	if int(seen) != len(items) {
+		t.Fatalf("got %d calls, want %d", seen, len(items))
+	}
+}
+
+func TestParallelDoAggregatesErrors(t *testing.T) {
+	g := &GCS{}
+	items := []string{"a", "b", "c"}
+	boom := errors.New("boom")
+	err := g.parallelDo(context.Background(), items, func(ctx context.Context, item string) error {
+		if item == "b" {
+			return boom
+		}
+		return nil
+	})
+	var multi *MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("got err %v, want *MultiError", err)
+	}
+	if len(multi.Errors) != 1 {
+		t.Fatalf("got %d errors, want 1 (only the failing item)", len(multi.Errors))
+	}
+}
+
+func TestParallelDoRunsEveryItemDespitePartialFailure(t *testing.T) {
+	// Regression test: a hand-rolled semaphore used to deadlock here because
+	// items skipped after the group's context was cancelled still tried to
+	// release a slot they never acquired. errgroup.SetLimit must let every
+	// item's goroutine run (and Wait must return) even when an early item
+	// fails.
+	g := &GCS{MaxConcurrency: 2}
+	items := []string{"a", "b", "c", "d", "e", "f"}
+	var calls int32
+	err := g.parallelDo(context.Background(), items, func(ctx context.Context, item string) error {
+		atomic.AddInt32(&calls, 1)
+		if item == "a" {
+			return errors.New("fails first")
+		}
+		return nil
+	})
+	if err == nil {
+		t.Fatal("got nil err, want non-nil")
+	}
+	if int(calls) != len(items) {
+		t.Fatalf("got %d calls, want %d (every item must still run)", calls, len(items))
+	}
+}
+
+func TestMaxConcurrencyDefault(t *testing.T) {
+	g := &GCS{}
+	if got := g.maxConcurrency(); got != DefaultMaxConcurrency {
+		t.Errorf("maxConcurrency() = %d, want %d", got, DefaultMaxConcurrency)
+	}
+	g.MaxConcurrency = 7
+	if got := g.maxConcurrency(); got != 7 {
+		t.Errorf("maxConcurrency() = %d, want 7", got)
+	}
+}
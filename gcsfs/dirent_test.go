@@ -0,0 +1,24 @@
+package gcsfs
+
+import "testing"
+
+func TestObjBaseName(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain object", "dir/file.txt", "file.txt"},
+		{"top-level object", "file.txt", "file.txt"},
+		{"directory prefix", "dir/sub/", "sub"},
+		{"top-level directory prefix", "dir/", "dir"},
+		{"no slash, no trailing slash", "file.txt", "file.txt"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := objBaseName(c.in); got != c.want {
+				t.Errorf("objBaseName(%q) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package gcsfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// fsFS adapts a *GCS, rooted at a prefix, to the stdlib io/fs interfaces.
+// It is returned by FromURL and by Sub, and is how callers hand a GCS
+// bucket to anything that accepts fs.FS (templates, http.FS, embeds, etc).
+type fsFS struct {
+	ctx    context.Context
+	gcs    *GCS
+	prefix string
+}
+
+// FromURL builds an fs.FS rooted at base, which must be a "gs://bucket/prefix"
+// or "file:///path" URL. The gs:// form is backed by GCS; the file:// form is
+// a thin wrapper around os.DirFS so callers can point the same code at a
+// local directory during tests.
+func FromURL(ctx context.Context, base string) (fs.FS, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "gs":
+		if u.Host == "" {
+			return nil, errors.New("gcsfs: gs:// URL missing bucket name")
+		}
+		return &fsFS{
+			ctx:    ctx,
+			gcs:    New(u.Host, WithBehaviour(Warn)),
+			prefix: strings.TrimPrefix(u.Path, "/"),
+		}, nil
+	case "file":
+		return os.DirFS(u.Path), nil
+	default:
+		return nil, fmt.Errorf("gcsfs: unsupported scheme %q in %q", u.Scheme, base)
+	}
+}
+
+func (f *fsFS) fullName(name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return f.prefix, nil
+	}
+	return path.Join(f.prefix, name), nil
+}
+
+// Open implements fs.FS.
+func (f *fsFS) Open(name string) (fs.File, error) {
+	full, err := f.fullName(name)
+	if err != nil {
+		return nil, err
+	}
+	client, err := f.gcs.getClient(f.ctx)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	reader, err := client.bucket.Object(full).NewReader(f.ctx)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &gcsFile{name: name, reader: reader}, nil
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (f *fsFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	full, err := f.fullName(name)
+	if err != nil {
+		return nil, err
+	}
+	if full != "" && !strings.HasSuffix(full, "/") {
+		full += "/"
+	}
+	infos, err := f.gcs.ReadDir(f.ctx, full)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	entries := make([]fs.DirEntry, len(infos))
+	for i, info := range infos {
+		entries[i] = fs.FileInfoToDirEntry(info)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// Stat implements fs.StatFS.
+func (f *fsFS) Stat(name string) (fs.FileInfo, error) {
+	full, err := f.fullName(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.gcs.Stat(f.ctx, full)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return info, nil
+}
+
+// Glob implements fs.GlobFS. It lists every object under the non-meta prefix
+// of pattern and filters with path.Match, since GCS has no native glob.
+func (f *fsFS) Glob(pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	listPrefix := pattern
+	if i := strings.IndexAny(listPrefix, "*?[\\"); i >= 0 {
+		listPrefix = listPrefix[:i]
+	}
+	client, err := f.gcs.getClient(f.ctx)
+	if err != nil {
+		return nil, err
+	}
+	iter := client.bucket.Objects(f.ctx, &storage.Query{Prefix: path.Join(f.prefix, listPrefix)})
+	var matches []string
+	for {
+		obj, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		rel := strings.TrimPrefix(obj.Name, f.prefix+"/")
+		ok, err := path.Match(pattern, rel)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matches = append(matches, rel)
+		}
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// Sub implements fs.SubFS, returning a new fs.FS rooted at dir.
+func (f *fsFS) Sub(dir string) (fs.FS, error) {
+	full, err := f.fullName(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &fsFS{ctx: f.ctx, gcs: f.gcs, prefix: full}, nil
+}
+
+// gcsFile wraps a *storage.Reader so it satisfies fs.File.
+type gcsFile struct {
+	name   string
+	reader *storage.Reader
+}
+
+func (g *gcsFile) Read(p []byte) (int, error) { return g.reader.Read(p) }
+func (g *gcsFile) Close() error               { return g.reader.Close() }
+
+func (g *gcsFile) Stat() (fs.FileInfo, error) {
+	attrs := g.reader.Attrs
+	return &FileInfo{
+		name:    path.Base(g.name),
+		size:    attrs.Size,
+		mode:    0666,
+		modTime: attrs.LastModified,
+		isDir:   false,
+	}, nil
+}
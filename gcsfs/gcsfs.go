@@ -8,14 +8,20 @@ import (
 	"io"
 	"io/fs"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gkalele/dfs/dfsapi"
 	"github.com/golang/glog"
 	"github.com/google/uuid"
+	"golang.org/x/oauth2"
 	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
 )
 
 type Behaviour int
@@ -30,8 +36,25 @@ const (
 type GCS struct {
 	BehaviourMode Behaviour
 	bucketName    string
+	clientOpts    []option.ClientOption
+
+	// MaxConcurrency bounds how many object RPCs RemoveAll, Rename and
+	// CopyPrefix issue at once. Zero means DefaultMaxConcurrency.
+	MaxConcurrency int
+
+	chunkSize          int
+	chunkRetryDeadline time.Duration
+	retryPolicy        RetryPolicy
+	uploadProgress     UploadProgress
+
+	clientMu sync.Mutex
+	client   *storage.Client
 }
 
+// UploadProgress is invoked after each chunk flushed to GCS during a
+// Create/CreateFile upload, with the cumulative number of bytes written.
+type UploadProgress func(name string, bytesWritten int64)
+
 type FileInfo struct {
 	name    string
 	size    int64
@@ -45,8 +68,104 @@ type FsInfo struct {
 	Name string
 }
 
-func New(bucketName string, behaviourMode Behaviour) *GCS {
-	return &GCS{BehaviourMode: behaviourMode, bucketName: bucketName}
+// Option configures a *GCS created by New, typically to control how it
+// authenticates with GCS.
+type Option func(*GCS)
+
+// WithBehaviour sets the Behaviour used for methods that have no GCS
+// equivalent (chmod, chown, ...). Defaults to InvalidBehaviour, which
+// panics the first time one of them is called unless overridden.
+func WithBehaviour(b Behaviour) Option {
+	return func(g *GCS) { g.BehaviourMode = b }
+}
+
+// WithClientOptions appends raw option.ClientOption values to those passed
+// to storage.NewClient, for anything not covered by a more specific Option.
+func WithClientOptions(opts ...option.ClientOption) Option {
+	return func(g *GCS) { g.clientOpts = append(g.clientOpts, opts...) }
+}
+
+// WithCredentialsJSON authenticates using a service-account or other JWT
+// credentials JSON key, instead of application default credentials.
+func WithCredentialsJSON(jsonKey []byte) Option {
+	return WithClientOptions(option.WithCredentialsJSON(jsonKey))
+}
+
+// WithTokenSource authenticates using a caller-supplied oauth2.TokenSource.
+func WithTokenSource(ts oauth2.TokenSource) Option {
+	return WithClientOptions(option.WithTokenSource(ts))
+}
+
+// WithHTTPClient swaps in a custom *http.Client, e.g. for proxying or
+// injecting test transports.
+func WithHTTPClient(hc *http.Client) Option {
+	return WithClientOptions(option.WithHTTPClient(hc))
+}
+
+// WithUserAgent sets a custom user-agent string on outgoing requests.
+func WithUserAgent(ua string) Option {
+	return WithClientOptions(option.WithUserAgent(ua))
+}
+
+// WithMaxConcurrency bounds how many object RPCs RemoveAll, Rename and
+// CopyPrefix issue at once. The default is DefaultMaxConcurrency.
+func WithMaxConcurrency(n int) Option {
+	return func(g *GCS) { g.MaxConcurrency = n }
+}
+
+// WithEmulatorHost points the client at a GCS emulator (e.g.
+// fake-gcs-server) at host instead of the real GCS endpoint, skipping
+// authentication entirely. This is equivalent to setting the
+// STORAGE_EMULATOR_HOST environment variable, but scoped to one *GCS.
+func WithEmulatorHost(host string) Option {
+	return WithClientOptions(option.WithEndpoint(host), option.WithoutAuthentication())
+}
+
+// New constructs a *GCS bound to bucketName. By default it authenticates
+// with application default credentials and lazily creates a single
+// *storage.Client the first time it is needed, reusing it for the life of
+// the *GCS. Pass Options to override credentials, target an emulator, or
+// inject a custom HTTP client/user-agent.
+func New(bucketName string, opts ...Option) *GCS {
+	g := &GCS{
+		bucketName:  bucketName,
+		retryPolicy: DefaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// WithChunkSize sets the chunk size (in bytes) used by subsequent
+// Create/CreateFile uploads. Passing 0 (or a negative value) leaves the
+// chunk size that storage.NewWriter sets by default in place.
+func (g *GCS) WithChunkSize(n int) *GCS {
+	g.chunkSize = n
+	return g
+}
+
+// WithChunkRetryDeadline bounds how long a single chunk upload may be
+// retried before Create/CreateFile gives up and returns the error.
+func (g *GCS) WithChunkRetryDeadline(d time.Duration) *GCS {
+	g.chunkRetryDeadline = d
+	return g
+}
+
+// WithRetryPolicy overrides the backoff policy used by Rename, CopyPrefix,
+// Remove and RemoveAll. Create/CreateFile uploads do not use it; their
+// chunks are retried by storage.Writer within ChunkRetryDeadline. The
+// default is DefaultRetryPolicy().
+func (g *GCS) WithRetryPolicy(p RetryPolicy) *GCS {
+	g.retryPolicy = p
+	return g
+}
+
+// WithUploadProgress registers a callback invoked after each chunk flushed
+// during a Create/CreateFile upload.
+func (g *GCS) WithUploadProgress(progress UploadProgress) *GCS {
+	g.uploadProgress = progress
+	return g
 }
 
 type TransactionClient struct {
@@ -55,18 +174,27 @@ type TransactionClient struct {
 	bucket *storage.BucketHandle
 }
 
-func (g *GCS) generateEphemeralClient(ctx context.Context) (*TransactionClient, error) {
-	client, err := storage.NewClient(ctx)
-	if err != nil {
-		return nil, err
+// getClient returns a TransactionClient wrapping the *GCS's *storage.Client,
+// creating it on first use. The client is expensive to construct (it dials
+// and authenticates) so it is cached on the *GCS and shared across calls
+// rather than recreated per-operation.
+func (g *GCS) getClient(ctx context.Context) (*TransactionClient, error) {
+	g.clientMu.Lock()
+	defer g.clientMu.Unlock()
+	if g.client == nil {
+		client, err := storage.NewClient(ctx, g.clientOpts...)
+		if err != nil {
+			return nil, err
+		}
+		g.client = client
 	}
-	bucket := client.Bucket(g.bucketName)
+	bucket := g.client.Bucket(g.bucketName)
 	if bucket == nil {
 		return nil, fmt.Errorf("bucket %s does not exist", g.bucketName)
 	}
 	return &TransactionClient{
 		txID:   uuid.New(),
-		client: client,
+		client: g.client,
 		bucket: bucket,
 	}, nil
 }
@@ -92,7 +220,7 @@ func (g *GCS) User(ctx context.Context) string {
 }
 
 func (g *GCS) ReadFile(ctx context.Context, filename string) ([]byte, error) {
-	client, err := g.generateEphemeralClient(ctx)
+	client, err := g.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +233,7 @@ func (g *GCS) ReadFile(ctx context.Context, filename string) ([]byte, error) {
 }
 
 func (g *GCS) CopyToLocal(ctx context.Context, src string, dst string) error {
-	client, err := g.generateEphemeralClient(ctx)
+	client, err := g.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -124,7 +252,7 @@ func (g *GCS) CopyToLocal(ctx context.Context, src string, dst string) error {
 }
 
 func (g *GCS) CopyToRemote(ctx context.Context, src string, dst string) error {
-	client, err := g.generateEphemeralClient(ctx)
+	client, err := g.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -140,12 +268,22 @@ func (g *GCS) CopyToRemote(ctx context.Context, src string, dst string) error {
 }
 
 // Close - there is nothing to do for GCS Close()
+// Close closes the cached *storage.Client, if one has been created, freeing
+// its underlying connection pool. The *GCS may still be used afterwards;
+// getClient will simply dial a new client on the next call.
 func (g *GCS) Close(ctx context.Context) error {
-	return nil
+	g.clientMu.Lock()
+	defer g.clientMu.Unlock()
+	if g.client == nil {
+		return nil
+	}
+	err := g.client.Close()
+	g.client = nil
+	return err
 }
 
 func (g *GCS) GetContentSummary(ctx context.Context, path string) (*dfsapi.ContentSummary, error) {
-	client, err := g.generateEphemeralClient(ctx)
+	client, err := g.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -164,7 +302,7 @@ func (g *GCS) GetContentSummary(ctx context.Context, path string) (*dfsapi.Conte
 }
 
 func (g *GCS) Open(ctx context.Context, name string) (dfsapi.FileReader, error) {
-	client, err := g.generateEphemeralClient(ctx)
+	client, err := g.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -172,24 +310,82 @@ func (g *GCS) Open(ctx context.Context, name string) (dfsapi.FileReader, error)
 }
 
 func (g *GCS) Create(ctx context.Context, name string) (dfsapi.FileWriter, error) {
-	client, err := g.generateEphemeralClient(ctx)
-	if err != nil {
-		return nil, err
-	}
-	return client.bucket.Object(name).NewWriter(ctx), nil
+	return g.createWriter(ctx, name, g.chunkSize)
 }
 
+// CreateFile implements the dfsapi.FileWriter-creating signature shared
+// across DFS backends; like os.OpenFile, flag and perm are accepted for
+// interface compatibility but unused by GCS, which has no notion of either.
 func (g *GCS) CreateFile(ctx context.Context, name string, _ int, _ int64, _ os.FileMode) (dfsapi.FileWriter, error) {
 	return g.Create(ctx, name)
 }
 
-func (g *GCS) Append(ctx context.Context, name string) (dfsapi.FileWriter, error) {
-	err := g.throwUnimplemented("Append method not implemented")
-	return nil, err
+// UploadOptions overrides the *GCS defaults (set via WithChunkSize,
+// WithChunkRetryDeadline, WithUploadProgress) for a single CreateWithOptions
+// call, without touching shared state on the receiver. A zero value for any
+// field falls back to the *GCS default.
+type UploadOptions struct {
+	ChunkSize          int
+	ChunkRetryDeadline time.Duration
+	Progress           UploadProgress
+}
+
+// CreateWithOptions is like Create but lets the caller pin chunk size,
+// chunk retry deadline, and upload progress for this upload only.
+func (g *GCS) CreateWithOptions(ctx context.Context, name string, opts UploadOptions) (dfsapi.FileWriter, error) {
+	client, err := g.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = g.chunkSize
+	}
+	chunkRetryDeadline := opts.ChunkRetryDeadline
+	if chunkRetryDeadline <= 0 {
+		chunkRetryDeadline = g.chunkRetryDeadline
+	}
+	progress := opts.Progress
+	if progress == nil {
+		progress = g.uploadProgress
+	}
+	writer := client.bucket.Object(name).NewWriter(ctx)
+	if chunkSize > 0 {
+		writer.ChunkSize = chunkSize
+	}
+	if chunkRetryDeadline > 0 {
+		writer.ChunkRetryDeadline = chunkRetryDeadline
+	}
+	return &gcsWriteCloser{
+		Writer:    writer,
+		name:      name,
+		chunkSize: chunkSize,
+		progress:  progress,
+	}, nil
+}
+
+func (g *GCS) createWriter(ctx context.Context, name string, chunkSize int) (dfsapi.FileWriter, error) {
+	client, err := g.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	writer := client.bucket.Object(name).NewWriter(ctx)
+	if chunkSize > 0 {
+		writer.ChunkSize = chunkSize
+	}
+	if g.chunkRetryDeadline > 0 {
+		writer.ChunkRetryDeadline = g.chunkRetryDeadline
+	}
+	return &gcsWriteCloser{
+		Writer:    writer,
+		name:      name,
+		chunkSize: chunkSize,
+		progress:  g.uploadProgress,
+	}, nil
 }
 
 func (g *GCS) CreateEmptyFile(ctx context.Context, name string) error {
-	client, err := g.generateEphemeralClient(ctx)
+	client, err := g.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -218,7 +414,7 @@ func (g *GCS) Chtimes(ctx context.Context, name string, atime time.Time, mtime t
 }
 
 func (g *GCS) ReadDir(ctx context.Context, dirname string) ([]os.FileInfo, error) {
-	client, err := g.generateEphemeralClient(ctx)
+	client, err := g.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -240,8 +436,36 @@ func (g *GCS) ReadDir(ctx context.Context, dirname string) ([]os.FileInfo, error
 			}
 			return nil, err
 		}
+		// A CommonPrefix (obj.Prefix set, obj.Name empty) is how GCS reports a
+		// "subdirectory" when listing with a delimiter.
+		if obj.Prefix != "" {
+			fileInfos = append(fileInfos, &FileInfo{
+				name:  objBaseName(obj.Prefix),
+				mode:  os.ModeDir | 0777,
+				isDir: true,
+			})
+			continue
+		}
+		// The directory's own placeholder marker (obj.Name == dirname) lists
+		// alongside its children when dirname itself is a zero-byte marker
+		// object; it names the directory, not a child of it, so skip it.
+		if obj.Name == dirname {
+			continue
+		}
+		// A zero-byte object whose name ends in "/" is a synthetic directory
+		// marker, the convention other GCS-backed filesystems (e.g. afero's)
+		// use to make an otherwise-empty directory visible.
+		if obj.Size == 0 && strings.HasSuffix(obj.Name, "/") {
+			fileInfos = append(fileInfos, &FileInfo{
+				name:    objBaseName(obj.Name),
+				mode:    os.ModeDir | 0777,
+				modTime: obj.Updated,
+				isDir:   true,
+			})
+			continue
+		}
 		fileInfos = append(fileInfos, &FileInfo{
-			name:    obj.Name,
+			name:    objBaseName(obj.Name),
 			size:    obj.Size,
 			mode:    0666,
 			modTime: obj.Updated,
@@ -252,87 +476,248 @@ func (g *GCS) ReadDir(ctx context.Context, dirname string) ([]os.FileInfo, error
 	return fileInfos, nil
 }
 
+// objBaseName returns the last "/"-separated component of a GCS object or
+// prefix name, with any trailing "/" stripped first.
+func objBaseName(name string) string {
+	name = strings.TrimSuffix(name, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
 func (g *GCS) Remove(ctx context.Context, name string) error {
-	client, err := g.generateEphemeralClient(ctx)
+	client, err := g.getClient(ctx)
 	if err != nil {
 		return err
 	}
-	return client.bucket.Object(name).Delete(ctx)
+	return RetryWithBackoff(ctx, g.retryPolicy, func() error {
+		return client.bucket.Object(name).Delete(ctx)
+	})
 }
 
-// RemoveAll removes path and any children it contains. It removes everything it
-// can but returns the first error it encounters. If the path does not exist,
-// RemoveAll returns nil (no error).
+// RemoveAll removes path and any children it contains, deleting up to
+// g.maxConcurrency() objects at once. Once one delete fails, the context
+// passed to the deletes still to run is cancelled, so they fail as well;
+// every error is aggregated into a *MultiError. If the path
+// does not exist, RemoveAll returns nil (no error).
 func (g *GCS) RemoveAll(ctx context.Context, dirname string) error {
-	client, err := g.generateEphemeralClient(ctx)
+	client, err := g.getClient(ctx)
 	if err != nil {
 		return err
 	}
-	iter := client.bucket.Objects(ctx, &storage.Query{
-		Delimiter:                "",
-		Prefix:                   dirname,
-		Versions:                 false,
-		IncludeTrailingDelimiter: false,
-		MatchGlob:                "",
-		IncludeFoldersAsPrefixes: false,
-		SoftDeleted:              false,
+	names, err := g.listNames(ctx, client, dirname)
+	if err != nil {
+		return err
+	}
+	return g.parallelDo(ctx, names, func(ctx context.Context, name string) error {
+		return RetryWithBackoff(ctx, g.retryPolicy, func() error {
+			return client.bucket.Object(name).Delete(ctx)
+		})
 	})
+}
+
+// listNames lists every object name under prefix, with no delimiter, so it
+// recurses through any "subdirectories".
+func (g *GCS) listNames(ctx context.Context, client *TransactionClient, prefix string) ([]string, error) {
+	iter := client.bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	var names []string
 	for {
 		obj, err := iter.Next()
 		if err != nil {
 			if errors.Is(err, iterator.Done) {
 				break
 			}
-			return err
-		}
-		if err = client.bucket.Object(obj.Name).Delete(ctx); err != nil {
-			return err
+			return nil, err
 		}
+		names = append(names, obj.Name)
 	}
-	return nil
+	return names, nil
 }
 
-// Rename an existing GCS object
-// GCS SDK Documentation states that we must copy the object to the new name and then delete the old one.
+// Rename an existing GCS object or prefix ("directory").
+// GCS SDK Documentation states that we must copy the object(s) to the new
+// name and then delete the original(s).
 //
 // > To move or rename an object using the JSON API directly, first make a copy of the object
 // > that has the properties you want and then delete the original object.
 //
 // Found the Copier() API that provides an elegant way to copy large objects.
+//
+// Rename always overwrites an existing destination; use
+// RenameWithOverwriteOption to reject the rename when the destination
+// already exists.
 func (g *GCS) Rename(ctx context.Context, oldpath, newpath string) error {
-	client, err := g.generateEphemeralClient(ctx)
+	return g.RenameWithOverwriteOption(ctx, oldpath, newpath, true)
+}
+
+// RenameWithOverwriteOption moves oldpath to newpath. If oldpath is a
+// prefix ("directory"), every object beneath it is moved, with up to
+// g.maxConcurrency() copies/deletes in flight at once.
+//
+// The move is implemented as server-side copies followed by deletes of the
+// originals: every source object is copied to its destination name first,
+// tracking the destinations that succeeded; if any copy fails, every
+// destination copied so far is deleted to roll back, and the originals are
+// left untouched. Only once every copy has succeeded are the originals
+// removed. overwrite controls whether an existing object at a destination
+// name is replaced (storage.Conditions.DoesNotExist is set accordingly).
+func (g *GCS) RenameWithOverwriteOption(ctx context.Context, oldpath, newpath string, overwrite bool) error {
+	client, err := g.getClient(ctx)
 	if err != nil {
 		return err
 	}
-	// Copier provides a resume token that allows resuming any failed copy operations
-	copier := client.bucket.Object(newpath).CopierFrom(client.bucket.Object(oldpath))
-	for retries := 0; retries < 5; retries++ {
-		if _, err = copier.Run(ctx); err == nil {
-			return nil
+
+	sources, err := g.renameSources(ctx, client, oldpath)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	copied := make([]string, 0, len(sources))
+	copyErr := g.parallelDo(ctx, sources, func(ctx context.Context, src string) error {
+		dst := renameDest(oldpath, newpath, src)
+		destObj := client.bucket.Object(dst)
+		if !overwrite {
+			destObj = destObj.If(storage.Conditions{DoesNotExist: true})
+		}
+		copier := destObj.CopierFrom(client.bucket.Object(src))
+		if err := RetryWithBackoff(ctx, g.retryPolicy, func() error {
+			_, err := copier.Run(ctx)
+			return err
+		}); err != nil {
+			return err
 		}
-		time.Sleep(1 * time.Second)
+		mu.Lock()
+		copied = append(copied, dst)
+		mu.Unlock()
+		return nil
+	})
+	if copyErr != nil {
+		// Roll back with a fresh context: ctx may itself be cancelled (which
+		// may be why a copy failed), and the rollback must still run.
+		g.rollbackCopies(context.Background(), client, copied)
+		return fmt.Errorf("gcsfs: rename %s -> %s: copying failed, rolled back %d copies: %w", oldpath, newpath, len(copied), copyErr)
 	}
-	return err
+
+	if deleteErr := g.parallelDo(ctx, sources, func(ctx context.Context, src string) error {
+		return RetryWithBackoff(ctx, g.retryPolicy, func() error {
+			return client.bucket.Object(src).Delete(ctx)
+		})
+	}); deleteErr != nil {
+		return fmt.Errorf("gcsfs: rename %s -> %s: all copies succeeded but deleting originals failed: %w", oldpath, newpath, deleteErr)
+	}
+	return nil
 }
 
-func (g *GCS) RenameWithOverwriteOption(ctx context.Context, oldpath, newpath string, overwrite bool) error {
-	return g.Rename(ctx, oldpath, newpath)
+// CopyPrefix bulk-copies oldprefix to newprefix without deleting the
+// sources, with up to g.maxConcurrency() copies in flight at once. Like
+// RenameWithOverwriteOption, overwrite controls whether an existing
+// destination object is replaced.
+func (g *GCS) CopyPrefix(ctx context.Context, oldprefix, newprefix string, overwrite bool) error {
+	client, err := g.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	sources, err := g.renameSources(ctx, client, oldprefix)
+	if err != nil {
+		return err
+	}
+	return g.parallelDo(ctx, sources, func(ctx context.Context, src string) error {
+		dst := renameDest(oldprefix, newprefix, src)
+		destObj := client.bucket.Object(dst)
+		if !overwrite {
+			destObj = destObj.If(storage.Conditions{DoesNotExist: true})
+		}
+		copier := destObj.CopierFrom(client.bucket.Object(src))
+		return RetryWithBackoff(ctx, g.retryPolicy, func() error {
+			_, err := copier.Run(ctx)
+			return err
+		})
+	})
 }
 
+// renameDest maps a source object name under oldpath to its destination
+// under newpath, treating a trailing "/" on either as insignificant so
+// oldpath="old" and oldpath="old/" (and likewise for newpath) produce the
+// same mapping.
+func renameDest(oldpath, newpath, src string) string {
+	oldBase := strings.TrimSuffix(oldpath, "/")
+	newBase := strings.TrimSuffix(newpath, "/")
+	return newBase + strings.TrimPrefix(src, oldBase)
+}
+
+// renameSources resolves oldpath to the set of object names Rename or
+// CopyPrefix should act on: oldpath itself if it names a single object, or
+// every object beneath it if it names a prefix.
+func (g *GCS) renameSources(ctx context.Context, client *TransactionClient, oldpath string) ([]string, error) {
+	if _, err := client.bucket.Object(oldpath).Attrs(ctx); err == nil {
+		return []string{oldpath}, nil
+	}
+
+	prefix := oldpath
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	names, err := g.listNames(ctx, client, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("gcsfs: rename source %s does not exist", oldpath)
+	}
+	return names, nil
+}
+
+// rollbackCopies best-effort deletes destination objects already copied by
+// an in-progress Rename after a later copy in the same rename fails.
+func (g *GCS) rollbackCopies(ctx context.Context, client *TransactionClient, copied []string) {
+	for _, name := range copied {
+		err := RetryWithBackoff(ctx, g.retryPolicy, func() error {
+			return client.bucket.Object(name).Delete(ctx)
+		})
+		if err != nil {
+			glog.Warningf("gcsfs: rename rollback: failed to delete partial copy %s: %s", name, err)
+		}
+	}
+}
+
+// Stat returns FileInfo for name. If name is not itself an object but is a
+// non-empty prefix of other objects (a "directory" with no zero-byte marker
+// object of its own), Stat still succeeds, returning a FileInfo with
+// IsDir() == true.
 func (g *GCS) Stat(ctx context.Context, name string) (os.FileInfo, error) {
-	client, err := g.generateEphemeralClient(ctx)
+	client, err := g.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 	attrs, err := client.bucket.Object(name).Attrs(ctx)
-	return &FileInfo{
-		name:    name,
-		size:    attrs.Size,
-		mode:    0666,
-		modTime: attrs.Updated,
-		isDir:   false,
-		sys:     nil,
-	}, nil
+	if err == nil {
+		isDir := attrs.Size == 0 && strings.HasSuffix(name, "/")
+		mode := os.FileMode(0666)
+		if isDir {
+			mode = os.ModeDir | 0777
+		}
+		return &FileInfo{
+			name:    objBaseName(name),
+			size:    attrs.Size,
+			mode:    mode,
+			modTime: attrs.Updated,
+			isDir:   isDir,
+		}, nil
+	}
+	if !errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, err
+	}
+
+	prefix := strings.TrimSuffix(name, "/") + "/"
+	iter := client.bucket.Objects(ctx, &storage.Query{Delimiter: "/", Prefix: prefix})
+	if _, iterErr := iter.Next(); iterErr == nil {
+		return &FileInfo{name: objBaseName(name), mode: os.ModeDir | 0777, isDir: true}, nil
+	} else if !errors.Is(iterErr, iterator.Done) {
+		return nil, iterErr
+	}
+	return nil, err
 }
 
 func (g *GCS) StatFs(_ context.Context) (dfsapi.FsInfo, error) {
@@ -341,8 +726,48 @@ func (g *GCS) StatFs(_ context.Context) (dfsapi.FsInfo, error) {
 	}, nil
 }
 
-func (g *GCS) Walk(_ context.Context, _ string, _ filepath.WalkFunc) error {
-	return g.throwUnimplemented("walk not implemented")
+// Walk walks the GCS "file tree" rooted at root, calling walkFn for root and
+// each of its descendants in lexical order, matching filepath.Walk's
+// semantics (including filepath.SkipDir support). Directories are
+// discovered via CommonPrefixes by listing with Delimiter: "/" one level at
+// a time, so the walk costs one list RPC per directory visited.
+func (g *GCS) Walk(ctx context.Context, root string, walkFn filepath.WalkFunc) error {
+	info, err := g.Stat(ctx, root)
+	if err != nil {
+		return walkFn(root, nil, err)
+	}
+	return g.walk(ctx, root, info, walkFn)
+}
+
+func (g *GCS) walk(ctx context.Context, name string, info os.FileInfo, walkFn filepath.WalkFunc) error {
+	if err := walkFn(name, info, nil); err != nil {
+		if info.IsDir() && errors.Is(err, filepath.SkipDir) {
+			return nil
+		}
+		return err
+	}
+	if !info.IsDir() {
+		return nil
+	}
+
+	prefix := strings.TrimSuffix(name, "/") + "/"
+	entries, err := g.ReadDir(ctx, prefix)
+	if err != nil {
+		return walkFn(name, info, err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	for _, entry := range entries {
+		if err := g.walk(ctx, prefix+entry.Name(), entry, walkFn); err != nil {
+			// walk already swallows SkipDir returned for a directory entry;
+			// SkipDir from a non-directory entry means stop walking the rest
+			// of this directory, matching filepath.Walk.
+			if errors.Is(err, filepath.SkipDir) {
+				break
+			}
+			return err
+		}
+	}
+	return nil
 }
 
 func (f *FileInfo) Name() string {
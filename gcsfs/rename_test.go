@@ -0,0 +1,26 @@
+package gcsfs
+
+import "testing"
+
+func TestRenameDest(t *testing.T) {
+	cases := []struct {
+		name             string
+		oldpath, newpath string
+		src              string
+		want             string
+	}{
+		{"no trailing slashes", "old", "new", "old/a", "new/a"},
+		{"trailing slash on oldpath only", "old/", "new", "old/a", "new/a"},
+		{"trailing slash on newpath only", "old", "new/", "old/a", "new/a"},
+		{"trailing slash on both", "old/", "new/", "old/a", "new/a"},
+		{"nested source path", "old/", "new/", "old/sub/b", "new/sub/b"},
+		{"single object rename", "old", "new", "old", "new"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := renameDest(c.oldpath, c.newpath, c.src); got != c.want {
+				t.Errorf("renameDest(%q, %q, %q) = %q, want %q", c.oldpath, c.newpath, c.src, got, c.want)
+			}
+		})
+	}
+}
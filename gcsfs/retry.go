@@ -0,0 +1,91 @@
+package gcsfs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// RetryPolicy controls how RetryWithBackoff retries a transient failure.
+// The zero value is not usable; use DefaultRetryPolicy() to get sane
+// defaults and override individual fields as needed.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+	// Multiplier scales the backoff after each attempt.
+	Multiplier float64
+	// Retryable reports whether err is worth retrying. A nil Retryable
+	// retries every non-nil error.
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy returns the exponential-backoff-with-jitter policy used
+// across Rename/Copy/Delete/Upload unless the caller overrides it. Its
+// Retryable only retries transient failures (5xx, 429, connection resets);
+// it does not retry permanent failures like a failed precondition.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Retryable:      isTransient,
+	}
+}
+
+// isTransient reports whether err looks like a transient failure worth
+// retrying: a 5xx or 429 response from GCS, or a network-level error such
+// as a connection reset. It deliberately does not retry 4xx failures like
+// the 412 Precondition Failed Rename/CopyPrefix get from
+// storage.Conditions{DoesNotExist: true} when overwrite is false, since
+// retrying those can never succeed.
+func isTransient(err error) bool {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code >= 500 || apiErr.Code == 429
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt))
+	if d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	// Full jitter: sleep somewhere between 0 and d.
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// RetryWithBackoff runs op until it succeeds, policy.Retryable rejects the
+// error, attempts are exhausted, or ctx is done. It is the shared retry loop
+// used by Rename, Copy, Delete and Upload in place of ad-hoc fixed sleeps.
+func RetryWithBackoff(ctx context.Context, policy RetryPolicy, op func() error) error {
+	var err error
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		if err = op(); err == nil {
+			return nil
+		}
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			return err
+		}
+		if attempt == policy.MaxAttempts-1 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(policy.backoff(attempt)):
+		}
+	}
+	return err
+}
@@ -0,0 +1,116 @@
+package gcsfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestIsTransient(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("boom"), false},
+		{"500", &googleapi.Error{Code: 500}, true},
+		{"503", &googleapi.Error{Code: 503}, true},
+		{"429", &googleapi.Error{Code: 429}, true},
+		{"404", &googleapi.Error{Code: 404}, false},
+		{"412 precondition failed", &googleapi.Error{Code: 412}, false},
+		{"wrapped 503", fmt.Errorf("copy: %w", &googleapi.Error{Code: 503}), true},
+		{"net error", &net.DNSError{IsTimeout: true}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := isTransient(c.err); got != c.want {
+				t.Errorf("isTransient(%v) = %v, want %v", c.err, got, c.want)
+			}
+		})
+	}
+}
+
+func TestBackoffBounded(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+	}
+	for attempt := 0; attempt < 10; attempt++ {
+		d := policy.backoff(attempt)
+		if d < 0 || d > policy.MaxBackoff {
+			t.Errorf("backoff(%d) = %v, want in [0, %v]", attempt, d, policy.MaxBackoff)
+		}
+	}
+}
+
+func TestRetryWithBackoffStopsOnNonRetryable(t *testing.T) {
+	nonRetryable := errors.New("permanent")
+	calls := 0
+	err := RetryWithBackoff(context.Background(), RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1,
+		Retryable:      func(error) bool { return false },
+	}, func() error {
+		calls++
+		return nonRetryable
+	})
+	if err != nonRetryable {
+		t.Fatalf("got err %v, want %v", err, nonRetryable)
+	}
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1 (should not retry a non-retryable error)", calls)
+	}
+}
+
+func TestRetryWithBackoffExhaustsAttempts(t *testing.T) {
+	retryable := errors.New("transient")
+	calls := 0
+	err := RetryWithBackoff(context.Background(), RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1,
+		Retryable:      func(error) bool { return true },
+	}, func() error {
+		calls++
+		return retryable
+	})
+	if err != retryable {
+		t.Fatalf("got err %v, want %v", err, retryable)
+	}
+	if calls != 3 {
+		t.Fatalf("got %d calls, want 3", calls)
+	}
+}
+
+func TestRetryWithBackoffSucceedsEventually(t *testing.T) {
+	calls := 0
+	err := RetryWithBackoff(context.Background(), RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1,
+		Retryable:      func(error) bool { return true },
+	}, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("got err %v, want nil", err)
+	}
+	if calls != 3 {
+		t.Fatalf("got %d calls, want 3", calls)
+	}
+}
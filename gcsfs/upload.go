@@ -0,0 +1,48 @@
+package gcsfs
+
+import "cloud.google.com/go/storage"
+
+// gcsWriteCloser wraps a *storage.Writer so UploadProgress, if set, is
+// invoked once per flushed chunk and once more on a successful Close.
+//
+// Close is NOT retried here: by the time Close is called, the writer's
+// source has already been fully consumed, so re-invoking Close cannot
+// re-upload anything and would just return the same cached error (or a
+// no-op). Resumable retries happen per-chunk, mid-upload, via
+// storage.Writer's own ChunkRetryDeadline; if Close itself fails, the
+// caller must retry the whole upload with a fresh writer.
+type gcsWriteCloser struct {
+	*storage.Writer
+	name      string
+	chunkSize int
+	progress  UploadProgress
+
+	written      int64
+	sinceFlushed int64
+}
+
+func (w *gcsWriteCloser) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.written += int64(n)
+	if w.progress == nil {
+		return n, err
+	}
+	w.sinceFlushed += int64(n)
+	if w.chunkSize > 0 {
+		for w.sinceFlushed >= int64(w.chunkSize) {
+			w.sinceFlushed -= int64(w.chunkSize)
+			w.progress(w.name, w.written-w.sinceFlushed)
+		}
+	}
+	return n, err
+}
+
+func (w *gcsWriteCloser) Close() error {
+	if err := w.Writer.Close(); err != nil {
+		return err
+	}
+	if w.progress != nil {
+		w.progress(w.name, w.written)
+	}
+	return nil
+}
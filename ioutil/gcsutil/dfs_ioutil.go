@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"time"
 
 	"cloud.google.com/go/storage"
+	"github.com/gkalele/dfs/gcsfs"
 	"github.com/gkalele/dfs/ioutil"
 )
 
@@ -17,6 +19,10 @@ type GCSUtil struct {
 	client     *storage.Client
 	bucketName string
 	bucket     *storage.BucketHandle
+
+	chunkSize          int
+	chunkRetryDeadline time.Duration
+	retryPolicy        gcsfs.RetryPolicy
 }
 
 func New(ctx context.Context, bucket string) (*GCSUtil, error) {
@@ -25,12 +31,35 @@ func New(ctx context.Context, bucket string) (*GCSUtil, error) {
 		return nil, err
 	}
 	return &GCSUtil{
-		client:     client,
-		bucketName: bucket,
-		bucket:     client.Bucket(bucket),
+		client:      client,
+		bucketName:  bucket,
+		bucket:      client.Bucket(bucket),
+		retryPolicy: gcsfs.DefaultRetryPolicy(),
 	}, nil
 }
 
+// WithChunkSize sets the chunk size (in bytes) used by subsequent calls to
+// StreamIntoDFS. Passing 0 (or a negative value) leaves the chunk size that
+// storage.NewWriter sets by default in place.
+func (g *GCSUtil) WithChunkSize(n int) *GCSUtil {
+	g.chunkSize = n
+	return g
+}
+
+// WithChunkRetryDeadline bounds how long StreamIntoDFS retries a single
+// chunk upload before giving up and returning the error.
+func (g *GCSUtil) WithChunkRetryDeadline(d time.Duration) *GCSUtil {
+	g.chunkRetryDeadline = d
+	return g
+}
+
+// WithRetryPolicy overrides the backoff policy used to retry StreamIntoDFS's
+// finalizing Close call. The default is gcsfs.DefaultRetryPolicy().
+func (g *GCSUtil) WithRetryPolicy(p gcsfs.RetryPolicy) *GCSUtil {
+	g.retryPolicy = p
+	return g
+}
+
 func (g *GCSUtil) StreamIntoDFS(ctx context.Context, reader io.Reader, name string, overwrite bool) (int64, error) {
 	ctxReader := ioutil.NewContextAwareReader(ctx, reader)
 	o := g.bucket.Object(name)
@@ -38,11 +67,17 @@ func (g *GCSUtil) StreamIntoDFS(ctx context.Context, reader io.Reader, name stri
 		o = o.If(storage.Conditions{DoesNotExist: true})
 	}
 	wc := o.NewWriter(ctx)
+	if g.chunkSize > 0 {
+		wc.ChunkSize = g.chunkSize
+	}
+	if g.chunkRetryDeadline > 0 {
+		wc.ChunkRetryDeadline = g.chunkRetryDeadline
+	}
 	n, err := io.Copy(wc, ctxReader)
 	if err != nil {
 		return n, fmt.Errorf("Error copying from input stream to gcs://%s :  %s", filepath.Join(g.bucketName, name), err)
 	}
-	if err = wc.Close(); err != nil {
+	if err := gcsfs.RetryWithBackoff(ctx, g.retryPolicy, wc.Close); err != nil {
 		return n, err
 	}
 	return n, nil
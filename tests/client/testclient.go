@@ -10,7 +10,7 @@ import (
 func main() {
 	bucketName := os.Args[1]
 	fmt.Printf("Testing GCSFS client using bucket %s\n", bucketName)
-	fs := gcsfs.New(bucketName, gcsfs.Panic)
+	fs := gcsfs.New(bucketName, gcsfs.WithBehaviour(gcsfs.Panic))
 
 	ctx := context.Background()
 	stat, err := fs.StatFs(ctx)